@@ -0,0 +1,158 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package batcher separates a stream of inputs (e.g. pending pods) into windows of batches, so that many items
+// arriving in a short span of time can be considered together for a single provisioning decision rather than one
+// at a time.
+package batcher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/karpenter/pkg/operator/options"
+)
+
+// Options configures the size of a batching window. MaxDuration bounds the total lifetime of a window no
+// matter what. Under the "fixed" strategy the idle window that can extend it is always IdleDuration; under
+// "exponential" it starts at MinDuration and grows by IdleMultiplier on each extension, capped at MaxDuration.
+type Options struct {
+	Strategy       string
+	MaxDuration    time.Duration
+	MinDuration    time.Duration
+	IdleDuration   time.Duration
+	IdleMultiplier float64
+}
+
+// OptionsFromCLI builds batcher Options from the Options.Batch* fields populated by
+// --batching-strategy/--batch-max-duration/--batch-min-duration/--batch-idle-duration/--batch-idle-multiplier, so
+// callers don't need to know which operator flags back which batcher knob.
+func OptionsFromCLI(o *options.Options) Options {
+	return Options{
+		Strategy:       o.BatchingStrategy,
+		MaxDuration:    o.BatchMaxDuration,
+		MinDuration:    o.BatchMinDuration,
+		IdleDuration:   o.BatchIdleDuration,
+		IdleMultiplier: o.BatchIdleMultiplier,
+	}
+}
+
+type request[T any] struct {
+	item     T
+	response chan struct{}
+}
+
+// Batcher collects items added via Add into windows. A window opens on the first Add() after the previous window
+// closed, and closes once its idle timer elapses with no further Add() calls, or once MaxDuration has elapsed
+// since the window opened, whichever comes first. Add() blocks until the window the item was added to closes.
+type Batcher[T any] struct {
+	opts Options
+
+	mu       sync.Mutex
+	open     bool
+	trigger  chan struct{}
+	requests chan request[T]
+}
+
+// NewBatcher returns a Batcher governed by opts.
+func NewBatcher[T any](opts Options) *Batcher[T] {
+	return &Batcher[T]{
+		opts:     opts,
+		trigger:  make(chan struct{}, 1),
+		requests: make(chan request[T]),
+	}
+}
+
+// Add enqueues item for the in-progress batch window, opening a new window if none is currently open, and blocks
+// until that window closes.
+func (b *Batcher[T]) Add(ctx context.Context, item T) {
+	b.mu.Lock()
+	if !b.open {
+		b.open = true
+		go b.window(ctx)
+	}
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	b.requests <- request[T]{item: item, response: done}
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// window runs the idle/max timers for a single batch window, extending the idle timer on every item received
+// (growing it under the "exponential" strategy) until the window closes.
+func (b *Batcher[T]) window(ctx context.Context) {
+	defer func() {
+		b.mu.Lock()
+		b.open = false
+		b.mu.Unlock()
+	}()
+
+	maxTimer := time.NewTimer(b.opts.MaxDuration)
+	defer maxTimer.Stop()
+	extensions := 0
+	idleTimer := time.NewTimer(b.nextIdleDuration(extensions))
+	defer idleTimer.Stop()
+
+	var pending []request[T]
+	for {
+		select {
+		case <-ctx.Done():
+			b.closeAll(pending)
+			return
+		case req := <-b.requests:
+			pending = append(pending, req)
+			extensions++
+			if !idleTimer.Stop() {
+				<-idleTimer.C
+			}
+			idleTimer.Reset(b.nextIdleDuration(extensions))
+		case <-idleTimer.C:
+			b.closeAll(pending)
+			return
+		case <-maxTimer.C:
+			b.closeAll(pending)
+			return
+		}
+	}
+}
+
+func (b *Batcher[T]) closeAll(pending []request[T]) {
+	for _, req := range pending {
+		close(req.response)
+	}
+}
+
+// nextIdleDuration returns the idle window to apply for the extension-th extension of the current batch window
+// (0 for the window's first idle timer, before any item has arrived). Under the "fixed" strategy this is always
+// opts.IdleDuration. Under "exponential" it starts at opts.MinDuration and grows by opts.IdleMultiplier on each
+// extension, never exceeding opts.MaxDuration.
+func (b *Batcher[T]) nextIdleDuration(extension int) time.Duration {
+	if b.opts.Strategy != "exponential" {
+		return b.opts.IdleDuration
+	}
+	d := b.opts.MinDuration
+	for i := 0; i < extension; i++ {
+		d = time.Duration(float64(d) * b.opts.IdleMultiplier)
+		if d >= b.opts.MaxDuration {
+			return b.opts.MaxDuration
+		}
+	}
+	return d
+}
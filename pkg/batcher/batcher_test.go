@@ -0,0 +1,58 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextIdleDurationFixed(t *testing.T) {
+	b := NewBatcher[int](Options{
+		Strategy:       "fixed",
+		MaxDuration:    10 * time.Second,
+		MinDuration:    time.Second,
+		IdleDuration:   2 * time.Second,
+		IdleMultiplier: 2,
+	})
+	for extension := 0; extension < 5; extension++ {
+		if got := b.nextIdleDuration(extension); got != 2*time.Second {
+			t.Errorf("extension %d: got %s, want %s", extension, got, 2*time.Second)
+		}
+	}
+}
+
+func TestNextIdleDurationExponential(t *testing.T) {
+	b := NewBatcher[int](Options{
+		Strategy:       "exponential",
+		MaxDuration:    10 * time.Second,
+		MinDuration:    time.Second,
+		IdleDuration:   2 * time.Second,
+		IdleMultiplier: 2,
+	})
+	for extension, want := range map[int]time.Duration{
+		0: time.Second,
+		1: 2 * time.Second,
+		2: 4 * time.Second,
+		3: 8 * time.Second,
+		4: 10 * time.Second, // capped at MaxDuration
+	} {
+		if got := b.nextIdleDuration(extension); got != want {
+			t.Errorf("extension %d: got %s, want %s", extension, got, want)
+		}
+	}
+}
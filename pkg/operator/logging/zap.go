@@ -0,0 +1,63 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging constructs the zap logger shared by all karpenter-core controllers.
+package logging
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"sigs.k8s.io/karpenter/pkg/operator/options"
+)
+
+// NewLogger builds the zap logger for the process from Options, rather than from an on-disk zap-logger-config
+// ConfigMap: encoding, level, and output paths are all sourced from CLI flags / env vars
+// (--log-encoding/--log-level/--log-output-paths/--log-error-output-paths) so operators can route controller logs
+// to files or sidecars without mounting a config file.
+func NewLogger(o *options.Options) (*zap.Logger, error) {
+	level, err := levelFromString(o.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("resolving log level, %w", err)
+	}
+	cfg := zap.NewProductionConfig()
+	cfg.Level = zap.NewAtomicLevelAt(level)
+	if o.LogEncoding != "" {
+		cfg.Encoding = o.LogEncoding
+	}
+	if len(o.LogOutputPaths) > 0 {
+		cfg.OutputPaths = o.LogOutputPaths
+	}
+	if len(o.LogErrorOutputPaths) > 0 {
+		cfg.ErrorOutputPaths = o.LogErrorOutputPaths
+	}
+	return cfg.Build()
+}
+
+func levelFromString(level string) (zapcore.Level, error) {
+	switch level {
+	case "", "info":
+		return zapcore.InfoLevel, nil
+	case "debug":
+		return zapcore.DebugLevel, nil
+	case "error":
+		return zapcore.ErrorLevel, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q", level)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package operator wires up the shared controller-runtime manager all karpenter-core controllers run under.
+package operator
+
+import (
+	"fmt"
+
+	"github.com/go-logr/zapr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/karpenter/pkg/operator/logging"
+	"sigs.k8s.io/karpenter/pkg/operator/options"
+)
+
+// NewManager constructs the controller-runtime manager karpenter-core's controllers register against. The
+// manager's logger is the zap logger built from Options (see pkg/operator/logging), and its leader election
+// behavior is tuned from
+// --leader-election-namespace/--leader-election-name/--leader-elect-lease-duration/--leader-elect-renew-deadline/--leader-elect-retry-period,
+// so HA deployments can tune failover behavior without forking the binary.
+func NewManager(o *options.Options) (ctrl.Manager, error) {
+	zapLogger, err := logging.NewLogger(o)
+	if err != nil {
+		return nil, fmt.Errorf("constructing logger, %w", err)
+	}
+	ctrl.SetLogger(zapr.NewLogger(zapLogger))
+	for _, w := range o.FeatureGates.DeprecationWarnings() {
+		zapLogger.Sugar().Warn(w)
+	}
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		LeaderElection:          o.EnableLeaderElection,
+		LeaderElectionNamespace: o.LeaderElectionNamespace,
+		LeaderElectionID:        o.LeaderElectionName,
+		LeaseDuration:           &o.LeaderElectionLeaseDuration,
+		RenewDeadline:           &o.LeaderElectionRenewDeadline,
+		RetryPeriod:             &o.LeaderElectionRetryPeriod,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("constructing manager, %w", err)
+	}
+	return mgr, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+
+	cliflag "k8s.io/component-base/cli/flag"
+)
+
+// Maturity describes the lifecycle stage of a feature gate, mirroring
+// k8s.io/component-base/featuregate.
+type Maturity string
+
+const (
+	Alpha Maturity = "ALPHA"
+	Beta  Maturity = "BETA"
+	GA    Maturity = "GA"
+)
+
+// FeatureSpec declares a single gate's name, default, and maturity. GA gates
+// are locked to their default: attempting to override one is not an error,
+// but it has no effect and emits a deprecation warning so operators notice
+// before the flag is removed entirely.
+//
+// To promote a gate (e.g. Drift alpha -> beta -> stable), flip its Maturity
+// (and Default, if the promotion also flips the default-on state) here -
+// callers keep using FeatureGates.Enabled(name) unchanged.
+type FeatureSpec struct {
+	Default  bool
+	Maturity Maturity
+}
+
+// knownFeatureGates is the registry of all feature gates karpenter-core
+// recognizes. Add an entry here to introduce a new gate; no other caller
+// needs to change.
+var knownFeatureGates = map[string]FeatureSpec{
+	"Drift":                   {Default: true, Maturity: GA},
+	"SpotToSpotConsolidation": {Default: false, Maturity: Alpha},
+}
+
+// FeatureGates holds the resolved enabled/disabled state of every known
+// feature gate, keyed by name. Use Enabled to query a gate rather than
+// reaching for a typed field, so that adding a gate never requires changing
+// the Options struct or its callers.
+type FeatureGates struct {
+	inputStr string
+	gates    map[string]bool
+
+	// deprecationWarnings accumulates messages for gate overrides that were
+	// ignored (e.g. a GA gate set to a non-default value). These aren't
+	// logged here: at parse time the zap logger configured by LogEncoding /
+	// LogOutputPaths doesn't exist yet, so logging here would mean reaching
+	// for a second, unconfigured logging framework. Surface them through
+	// DeprecationWarnings once the real logger is constructed instead.
+	deprecationWarnings []string
+}
+
+// DeprecationWarnings returns any messages produced while parsing feature
+// gates that should be surfaced through the configured logger once it's
+// available, e.g. `feature gate "Drift" is GA and locked to true, ignoring
+// override to false`.
+func (f FeatureGates) DeprecationWarnings() []string {
+	return f.deprecationWarnings
+}
+
+// Enabled returns whether the named feature gate is currently enabled. A
+// registered gate with no resolved state (e.g. a FeatureGates that did not
+// come from ParseFeatureGates) reports its registered default; a name that is
+// not in the registry reports false.
+func (f FeatureGates) Enabled(name string) bool {
+	if val, ok := f.gates[name]; ok {
+		return val
+	}
+	if spec, ok := knownFeatureGates[name]; ok {
+		return spec.Default
+	}
+	return false
+}
+
+// ParseFeatureGates parses a comma-separated key=value gate string (e.g.
+// "Drift=true,SpotToSpotConsolidation=true") against the knownFeatureGates
+// registry. Unknown gate names are rejected. GA gates may be set to their
+// own default without error; setting one to a non-default value is ignored
+// with a deprecation warning rather than failing, so that a stale flag left
+// over from a promoted gate doesn't break startup.
+func ParseFeatureGates(gateStr string) (FeatureGates, error) {
+	rawMap := map[string]bool{}
+	if err := cliflag.NewMapStringBool(&rawMap).Set(gateStr); err != nil {
+		return FeatureGates{}, err
+	}
+
+	gates := FeatureGates{inputStr: gateStr, gates: map[string]bool{}}
+	for name, val := range rawMap {
+		spec, ok := knownFeatureGates[name]
+		if !ok {
+			return FeatureGates{}, fmt.Errorf("unknown feature gate %q", name)
+		}
+		if spec.Maturity == GA && val != spec.Default {
+			gates.deprecationWarnings = append(gates.deprecationWarnings,
+				fmt.Sprintf("feature gate %q is GA and locked to %t, ignoring override to %t", name, spec.Default, val))
+			continue
+		}
+		gates.gates[name] = val
+	}
+	for name, spec := range knownFeatureGates {
+		if _, ok := gates.gates[name]; !ok {
+			gates.gates[name] = spec.Default
+		}
+	}
+	return gates, nil
+}
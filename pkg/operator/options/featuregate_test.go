@@ -0,0 +1,51 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import "testing"
+
+func TestParseFeatureGatesDefaults(t *testing.T) {
+	gates, err := ParseFeatureGates("")
+	if err != nil {
+		t.Fatalf("ParseFeatureGates() error = %v", err)
+	}
+	if !gates.Enabled("Drift") {
+		t.Error("Drift should default to enabled")
+	}
+	if gates.Enabled("SpotToSpotConsolidation") {
+		t.Error("SpotToSpotConsolidation should default to disabled")
+	}
+}
+
+func TestParseFeatureGatesGALocked(t *testing.T) {
+	gates, err := ParseFeatureGates("Drift=false")
+	if err != nil {
+		t.Fatalf("ParseFeatureGates() error = %v", err)
+	}
+	if !gates.Enabled("Drift") {
+		t.Error("overriding a GA gate should be ignored, Drift should remain enabled")
+	}
+	if len(gates.DeprecationWarnings()) != 1 {
+		t.Errorf("expected 1 deprecation warning, got %d", len(gates.DeprecationWarnings()))
+	}
+}
+
+func TestParseFeatureGatesUnknown(t *testing.T) {
+	if _, err := ParseFeatureGates("NotARealGate=true"); err == nil {
+		t.Error("expected an error for an unknown feature gate")
+	}
+}
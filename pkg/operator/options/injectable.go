@@ -0,0 +1,59 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"context"
+	"fmt"
+)
+
+// Injectable is the extension point that lets cloudproviders and other
+// subsystems (e.g. AWS, Azure, kwok) contribute their own flags and env vars
+// to the shared CLI without karpenter-core knowing about them. Appending an
+// implementation to Injectables is enough to have its flags parsed alongside
+// core's and its resolved options injected into the root context.
+//
+// Implementations should keep their own options struct private to their
+// package and inject/retrieve it from context with ToContextT/FromContextT
+// under a key private to that package, rather than reusing core's Options.
+type Injectable interface {
+	AddFlags(fs *FlagSet)
+	Parse(fs *FlagSet, args ...string) error
+	ToContext(ctx context.Context) context.Context
+}
+
+// injectableKey namespaces a context value by the concrete options type T, so
+// that each package registering an Injectable gets its own private slot in
+// context without needing to declare its own key type.
+type injectableKey[T any] struct{}
+
+// ToContextT injects opts into ctx under a key private to type T. Pair with
+// FromContextT[T] to retrieve it.
+func ToContextT[T any](ctx context.Context, opts *T) context.Context {
+	return context.WithValue(ctx, injectableKey[T]{}, opts)
+}
+
+// FromContextT retrieves the *T previously injected with ToContextT. It
+// panics if no value of type T was injected, mirroring FromContext's
+// treatment of a missing Options as a developer error.
+func FromContextT[T any](ctx context.Context) *T {
+	retval := ctx.Value(injectableKey[T]{})
+	if retval == nil {
+		panic(fmt.Sprintf("%T doesn't exist in context", *new(T)))
+	}
+	return retval.(*T)
+}
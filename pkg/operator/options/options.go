@@ -22,44 +22,60 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/samber/lo"
-	cliflag "k8s.io/component-base/cli/flag"
 
 	"sigs.k8s.io/karpenter/pkg/utils/env"
 )
 
 var (
-	validLogLevels = []string{"", "debug", "info", "error"}
-
+	validLogLevels          = []string{"", "debug", "info", "error"}
+	validLogEncodings       = []string{"", "json", "console"}
+	validBatchingStrategies = []string{"fixed", "exponential"}
+
+	// Injectables is the set of Injectable implementations whose flags get
+	// added to the shared FlagSet and whose options get injected into the
+	// root context on startup. Core registers its own Options here; other
+	// packages (cloudproviders, kwok, etc.) append their own Injectable from
+	// an init() in their own package rather than patching this slice here.
 	Injectables = []Injectable{&Options{}}
 )
 
-type optionsKey struct{}
-
-type FeatureGates struct {
-	Drift    bool
-	inputStr string
-}
-
 // Options contains all CLI flags / env vars for karpenter-core. It adheres to the options.Injectable interface.
 type Options struct {
-	ServiceName          string
-	DisableWebhook       bool
-	WebhookPort          int
-	MetricsPort          int
-	WebhookMetricsPort   int
-	HealthProbePort      int
-	KubeClientQPS        int
-	KubeClientBurst      int
-	EnableProfiling      bool
-	EnableLeaderElection bool
-	MemoryLimit          int64
-	LogLevel             string
-	BatchMaxDuration     time.Duration
-	BatchIdleDuration    time.Duration
-	FeatureGates         FeatureGates
+	ServiceName                 string
+	DisableWebhook              bool
+	WebhookPort                 int
+	MetricsPort                 int
+	WebhookMetricsPort          int
+	HealthProbePort             int
+	KubeClientQPS               int
+	KubeClientBurst             int
+	EnableProfiling             bool
+	EnableLeaderElection        bool
+	LeaderElectionNamespace     string
+	LeaderElectionName          string
+	LeaderElectionLeaseDuration time.Duration
+	LeaderElectionRenewDeadline time.Duration
+	LeaderElectionRetryPeriod   time.Duration
+	MemoryLimit                 int64
+	LogLevel                    string
+	LogEncoding                 string
+	LogOutputPaths              []string
+	LogErrorOutputPaths         []string
+	BatchingStrategy            string
+	BatchMaxDuration            time.Duration
+	BatchMinDuration            time.Duration
+	BatchIdleDuration           time.Duration
+	BatchIdleMultiplier         float64
+	VMMemoryOverheadPercent     float64
+	FeatureGates                FeatureGates
+
+	logOutputPathsStr      string
+	logErrorOutputPathsStr string
 }
 
 type FlagSet struct {
@@ -79,6 +95,19 @@ func (fs *FlagSet) BoolVarWithEnv(p *bool, name string, envVar string, val bool,
 	})
 }
 
+// Float64VarWithEnv defines a float64 flag with a specified name, default value, usage string, and fallback
+// environment variable. There's no env.WithDefaultFloat64 equivalent to the other env.WithDefault* helpers, so the
+// env var is parsed here directly rather than introducing one just for these two flags.
+func (fs *FlagSet) Float64VarWithEnv(p *float64, name string, envVar string, val float64, usage string) {
+	if raw, ok := os.LookupEnv(envVar); ok {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err == nil {
+			val = parsed
+		}
+	}
+	fs.Float64Var(p, name, val, usage)
+}
+
 func (o *Options) AddFlags(fs *FlagSet) {
 	fs.StringVar(&o.ServiceName, "karpenter-service", env.WithDefaultString("KARPENTER_SERVICE", ""), "The Karpenter Service name for the dynamic webhook certificate")
 	fs.BoolVarWithEnv(&o.DisableWebhook, "disable-webhook", "DISABLE_WEBHOOK", true, "Disable the admission and validation webhooks")
@@ -90,11 +119,23 @@ func (o *Options) AddFlags(fs *FlagSet) {
 	fs.IntVar(&o.KubeClientBurst, "kube-client-burst", env.WithDefaultInt("KUBE_CLIENT_BURST", 300), "The maximum allowed burst of queries to the kube-apiserver")
 	fs.BoolVarWithEnv(&o.EnableProfiling, "enable-profiling", "ENABLE_PROFILING", false, "Enable the profiling on the metric endpoint")
 	fs.BoolVarWithEnv(&o.EnableLeaderElection, "leader-elect", "LEADER_ELECT", true, "Start leader election client and gain leadership before executing the main loop. Enable this when running replicated components for high availability.")
+	fs.StringVar(&o.LeaderElectionNamespace, "leader-election-namespace", env.WithDefaultString("LEADER_ELECTION_NAMESPACE", ""), "Namespace in which to create the leader election resource. Defaults to the controller's own namespace if left unset")
+	fs.StringVar(&o.LeaderElectionName, "leader-election-name", env.WithDefaultString("LEADER_ELECTION_NAME", "karpenter-leader-election"), "Name of the leader election resource to use for holding leadership")
+	fs.DurationVar(&o.LeaderElectionLeaseDuration, "leader-elect-lease-duration", env.WithDefaultDuration("LEADER_ELECT_LEASE_DURATION", 15*time.Second), "Duration that non-leader candidates will wait to force acquire leadership. This is measured against time of last observed ack.")
+	fs.DurationVar(&o.LeaderElectionRenewDeadline, "leader-elect-renew-deadline", env.WithDefaultDuration("LEADER_ELECT_RENEW_DEADLINE", 10*time.Second), "Duration that the acting leader will retry refreshing leadership before giving up")
+	fs.DurationVar(&o.LeaderElectionRetryPeriod, "leader-elect-retry-period", env.WithDefaultDuration("LEADER_ELECT_RETRY_PERIOD", 2*time.Second), "Duration the LeaderElector clients should wait between tries of actions")
 	fs.Int64Var(&o.MemoryLimit, "memory-limit", env.WithDefaultInt64("MEMORY_LIMIT", -1), "Memory limit on the container running the controller. The GC soft memory limit is set to 90% of this value.")
 	fs.StringVar(&o.LogLevel, "log-level", env.WithDefaultString("LOG_LEVEL", "info"), "Log verbosity level. Can be one of 'debug', 'info', or 'error'")
+	fs.StringVar(&o.LogEncoding, "log-encoding", env.WithDefaultString("LOG_ENCODING", ""), "The log encoding to use, either 'json' or 'console'. Defaults to the zap production encoder if left unset")
+	fs.StringVar(&o.logOutputPathsStr, "log-output-paths", env.WithDefaultString("LOG_OUTPUT_PATHS", "stdout"), "Optional comma-separated set of paths to write log output to, e.g. 'stdout,/var/log/karpenter.log'")
+	fs.StringVar(&o.logErrorOutputPathsStr, "log-error-output-paths", env.WithDefaultString("LOG_ERROR_OUTPUT_PATHS", "stderr"), "Optional comma-separated set of paths to write internal logger errors to, e.g. 'stderr,/var/log/karpenter-error.log'")
+	fs.StringVar(&o.BatchingStrategy, "batching-strategy", env.WithDefaultString("BATCHING_STRATEGY", "fixed"), "The strategy used to grow the batching idle window on repeated pod arrivals. Can be one of 'fixed' or 'exponential'")
 	fs.DurationVar(&o.BatchMaxDuration, "batch-max-duration", env.WithDefaultDuration("BATCH_MAX_DURATION", 10*time.Second), "The maximum length of a batch window. The longer this is, the more pods we can consider for provisioning at one time which usually results in fewer but larger nodes.")
-	fs.DurationVar(&o.BatchIdleDuration, "batch-idle-duration", env.WithDefaultDuration("BATCH_IDLE_DURATION", time.Second), "The maximum amount of time with no new pending pods that if exceeded ends the current batching window. If pods arrive faster than this time, the batching window will be extended up to the maxDuration. If they arrive slower, the pods will be batched separately.")
-	fs.StringVar(&o.FeatureGates.inputStr, "feature-gates", env.WithDefaultString("FEATURE_GATES", "Drift=true"), "Optional features can be enabled / disabled using feature gates. Current options are: Drift")
+	fs.DurationVar(&o.BatchMinDuration, "batch-min-duration", env.WithDefaultDuration("BATCH_MIN_DURATION", time.Second), "The starting length of a batch idle window when using the 'exponential' batching strategy, grown by batch-idle-multiplier on each extension up to batch-max-duration. Ignored when the batching strategy is 'fixed'.")
+	fs.DurationVar(&o.BatchIdleDuration, "batch-idle-duration", env.WithDefaultDuration("BATCH_IDLE_DURATION", time.Second), "The amount of time with no new pending pods that if exceeded ends the current batching window, when using the 'fixed' batching strategy. If pods arrive faster than this time, the batching window will be extended up to batch-max-duration. If they arrive slower, the pods will be batched separately. Ignored when the batching strategy is 'exponential' (use batch-min-duration for the starting idle window there).")
+	fs.Float64VarWithEnv(&o.BatchIdleMultiplier, "batch-idle-multiplier", "BATCH_IDLE_MULTIPLIER", 1.5, "The factor by which the batch idle window (starting from batch-min-duration) grows on each extension when using the 'exponential' batching strategy, up to batch-max-duration. Ignored when the batching strategy is 'fixed'.")
+	fs.Float64VarWithEnv(&o.VMMemoryOverheadPercent, "vm-memory-overhead-percent", "VM_MEMORY_OVERHEAD_PERCENT", 0.075, "The VM memory overhead as a percent that will be subtracted from the total memory for all instance types when computing schedulable capacity")
+	fs.StringVar(&o.FeatureGates.inputStr, "feature-gates", env.WithDefaultString("FEATURE_GATES", "Drift=true"), "Optional features can be enabled / disabled using feature gates. Current options are: Drift, SpotToSpotConsolidation")
 }
 
 func (o *Options) Parse(fs *FlagSet, args ...string) error {
@@ -108,6 +149,18 @@ func (o *Options) Parse(fs *FlagSet, args ...string) error {
 	if !lo.Contains(validLogLevels, o.LogLevel) {
 		return fmt.Errorf("validating cli flags / env vars, invalid log level %q", o.LogLevel)
 	}
+	if !lo.Contains(validLogEncodings, o.LogEncoding) {
+		return fmt.Errorf("validating cli flags / env vars, invalid log encoding %q", o.LogEncoding)
+	}
+	if !lo.Contains(validBatchingStrategies, o.BatchingStrategy) {
+		return fmt.Errorf("validating cli flags / env vars, invalid batching strategy %q", o.BatchingStrategy)
+	}
+	if o.VMMemoryOverheadPercent < 0 || o.VMMemoryOverheadPercent >= 1 {
+		return fmt.Errorf("validating cli flags / env vars, invalid vm-memory-overhead-percent %f, must be in [0, 1)", o.VMMemoryOverheadPercent)
+	}
+	o.LogOutputPaths = splitCommaSeparated(o.logOutputPathsStr)
+	o.LogErrorOutputPaths = splitCommaSeparated(o.logErrorOutputPathsStr)
+
 	gates, err := ParseFeatureGates(o.FeatureGates.inputStr)
 	if err != nil {
 		return fmt.Errorf("parsing feature gates, %w", err)
@@ -116,35 +169,29 @@ func (o *Options) Parse(fs *FlagSet, args ...string) error {
 	return nil
 }
 
-func (o *Options) ToContext(ctx context.Context) context.Context {
-	return ToContext(ctx, o)
+// splitCommaSeparated splits a comma-separated flag value into its
+// constituent, whitespace-trimmed entries, e.g. for log-output-paths.
+// Empty entries (from a trailing comma or an empty override) are dropped.
+func splitCommaSeparated(s string) []string {
+	return lo.FilterMap(strings.Split(s, ","), func(p string, _ int) (string, bool) {
+		p = strings.TrimSpace(p)
+		return p, p != ""
+	})
 }
 
-func ParseFeatureGates(gateStr string) (FeatureGates, error) {
-	gateMap := map[string]bool{}
-	gates := FeatureGates{}
-
-	// Parses feature gates with the upstream mechanism. This is meant to be used with flag directly but this enables
-	// simple merging with environment vars.
-	if err := cliflag.NewMapStringBool(&gateMap).Set(gateStr); err != nil {
-		return gates, err
-	}
-	if val, ok := gateMap["Drift"]; ok {
-		gates.Drift = val
-	}
-
-	return gates, nil
+func (o *Options) ToContext(ctx context.Context) context.Context {
+	return ToContext(ctx, o)
 }
 
+// ToContext and FromContext keep their historical, non-generic signatures so
+// that existing callers (options.FromContext(ctx).FeatureGates.Enabled(...))
+// don't need to change. They're now implemented in terms of ToContextT /
+// FromContextT, the same mechanism other packages' Injectables use to inject
+// their own options under their own private key.
 func ToContext(ctx context.Context, opts *Options) context.Context {
-	return context.WithValue(ctx, optionsKey{}, opts)
+	return ToContextT[Options](ctx, opts)
 }
 
 func FromContext(ctx context.Context) *Options {
-	retval := ctx.Value(optionsKey{})
-	if retval == nil {
-		// This is a developer error if this happens, so we should panic
-		panic("options doesn't exist in context")
-	}
-	return retval.(*Options)
+	return FromContextT[Options](ctx)
 }
@@ -0,0 +1,60 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+)
+
+func TestSplitCommaSeparated(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want []string
+	}{
+		{"stdout", []string{"stdout"}},
+		{"stdout,stderr", []string{"stdout", "stderr"}},
+		{"stdout, stderr", []string{"stdout", "stderr"}},
+		{"stdout,", []string{"stdout"}},
+		{"", []string{}},
+	} {
+		if got := splitCommaSeparated(tc.in); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("splitCommaSeparated(%q) = %#v, want %#v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParseInvalidVMMemoryOverheadPercent(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		args []string
+	}{
+		{"negative", []string{"--vm-memory-overhead-percent=-0.1"}},
+		{"one", []string{"--vm-memory-overhead-percent=1"}},
+		{"greater than one", []string{"--vm-memory-overhead-percent=1.5"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := &Options{}
+			fs := &FlagSet{flag.NewFlagSet("test", flag.ContinueOnError)}
+			opts.AddFlags(fs)
+			if err := opts.Parse(fs, tc.args...); err == nil {
+				t.Errorf("expected an error for %v", tc.args)
+			}
+		})
+	}
+}
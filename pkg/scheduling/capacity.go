@@ -0,0 +1,44 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/karpenter/pkg/operator/options"
+)
+
+// MemoryOverhead returns the portion of an instance type's raw memory capacity that must be reserved for
+// kernel/kubelet overhead before it's schedulable, per --vm-memory-overhead-percent. Cloudprovider
+// implementations should subtract this from the raw memory their API reports when computing an instance type's
+// schedulable (allocatable) memory capacity, so that the overhead is configurable rather than a fixed 0.075
+// baked into every provider's calculation.
+func MemoryOverhead(ctx context.Context, totalMemory resource.Quantity) resource.Quantity {
+	overheadPercent := options.FromContext(ctx).VMMemoryOverheadPercent
+	overhead := int64(float64(totalMemory.Value()) * overheadPercent)
+	return *resource.NewQuantity(overhead, totalMemory.Format)
+}
+
+// SchedulableMemory returns the memory capacity of an instance type after subtracting the configured VM memory
+// overhead from its raw, API-reported total.
+func SchedulableMemory(ctx context.Context, totalMemory resource.Quantity) resource.Quantity {
+	schedulable := totalMemory.DeepCopy()
+	schedulable.Sub(MemoryOverhead(ctx, totalMemory))
+	return schedulable
+}
@@ -0,0 +1,38 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/karpenter/pkg/operator/options"
+)
+
+func TestSchedulableMemory(t *testing.T) {
+	opts := &options.Options{VMMemoryOverheadPercent: 0.1}
+	ctx := opts.ToContext(context.Background())
+
+	total := resource.MustParse("1000Mi")
+	got := SchedulableMemory(ctx, total)
+	want := resource.MustParse("900Mi")
+	if got.Cmp(want) != 0 {
+		t.Errorf("SchedulableMemory() = %s, want %s", got.String(), want.String())
+	}
+}